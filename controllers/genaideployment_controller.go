@@ -19,23 +19,66 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
 	rayv1 "github.com/splunk/splunk-operator/controllers/ray/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+	"github.com/splunk/splunk-operator/pkg/genai/vectordb"
+	"github.com/splunk/splunk-operator/pkg/splunk/statuscheck"
+	"github.com/splunk/splunk-operator/pkg/splunk/util"
+)
+
+// readinessPollTimeout bounds how long the reconciler will wait inline for a
+// just-created-or-updated child resource to become ready before giving up and
+// requeuing. It is intentionally short: a still-rolling-out child is not an
+// error, so we'd rather requeue than block the reconcile loop.
+const readinessPollTimeout = 3 * time.Second
+
+// Condition reasons surfaced on GenAIDeployment.Status.Conditions while children
+// are rolling out.
+const (
+	reasonRayClusterNotReady = "RayClusterNotReady"
+	reasonSaisNotReady       = "SaisServiceNotReady"
+	reasonVectorDBIndexing   = "VectorDBIndexing"
 )
 
+// GPU vendors supported by Spec.SaisService.GPU and a RayService worker group's
+// GPU block. Only nvidia's device plugin node-feature-discovery labels are
+// reflected into nodeSelector today; the other vendors still get the right
+// resource limit, RuntimeClassName, and toleration.
+const (
+	gpuVendorNVIDIA = "nvidia"
+	gpuVendorAMD    = "amd"
+	gpuVendorIntel  = "intel"
+)
+
+// modelCacheMountPath is where the shared model cache is mounted into SaisService
+// and Ray worker containers when Spec.ModelCache is set.
+const modelCacheMountPath = "/model-cache"
+
+// modelCacheVolumeName names both the PVC (or hostPath volume) and the pod Volume
+// that carry the warmed model cache.
+const modelCacheVolumeName = "model-cache"
+
 // GenAIDeploymentReconciler reconciles a GenAIDeployment object
 type GenAIDeploymentReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=enterprise.splunk.com,resources=genaideployments,verbs=get;list;watch;create;update;patch;delete
@@ -57,153 +100,413 @@ func (r *GenAIDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// Handle RayCluster creation/update
+	// Resolve the batch scheduler named by Spec.BatchScheduler and gang-schedule the
+	// deployment's Ray/SaisService/VectorDb pods before submitting any of them.
+	scheduler, err := batchscheduler.NewSchedulerManager(r.Client, r.Scheme).GetScheduler(genAIDeployment)
+	if err != nil {
+		reqLogger.Error(err, "Failed to resolve batch scheduler")
+		return ctrl.Result{}, err
+	}
+	if err := scheduler.DoBatchSchedulingOnSubmission(ctx, genAIDeployment); err != nil {
+		reqLogger.Error(err, "Failed to submit batch scheduling PodGroup")
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the shared model-cache PVC (if Spec.ModelCache asks for one) so its
+	// name can be mounted read-only into the SaisService and Ray worker pods below.
+	modelCachePVCName, err := r.reconcileModelCachePVC(ctx, genAIDeployment)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile model cache PVC")
+		return ctrl.Result{}, err
+	}
+
+	// Handle RayService creation/update. RayService owns the RayCluster for us and
+	// layers Serve application + zero-downtime upgrade management on top of it.
 	if genAIDeployment.Spec.RayService.Enabled {
-		rayCluster := &rayv1.RayCluster{}
-		err := r.Client.Get(ctx, types.NamespacedName{Name: req.Name + "-raycluster", Namespace: req.Namespace}, rayCluster)
+		rayService := &rayv1.RayService{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: req.Name + "-rayservice", Namespace: req.Namespace}, rayService)
 		if err != nil {
-			// Create RayCluster if not found
-			newRayCluster := r.constructRayCluster(ctx, genAIDeployment)
-			if err := r.Client.Create(ctx, newRayCluster); err != nil {
-				reqLogger.Error(err, "Failed to create RayCluster")
+			if client.IgnoreNotFound(err) != nil {
+				reqLogger.Error(err, "Failed to get RayService")
 				return ctrl.Result{}, err
 			}
+
+			// Create RayService if not found
+			newRayService := r.constructRayService(ctx, genAIDeployment, scheduler, modelCachePVCName)
+			if err := r.Client.Create(ctx, newRayService); err != nil {
+				reqLogger.Error(err, "Failed to create RayService")
+				return ctrl.Result{}, err
+			}
+			rayService = newRayService
 		} else {
-			// Update existing RayCluster if necessary
-			updatedRayCluster := r.updateRayCluster(ctx, rayCluster, genAIDeployment)
-			if err := r.Client.Update(ctx, updatedRayCluster); err != nil {
-				reqLogger.Error(err, "Failed to update RayCluster")
+			// Update existing RayService if necessary
+			updatedRayService := r.updateRayService(ctx, rayService, genAIDeployment, scheduler, modelCachePVCName)
+			if err := r.Client.Update(ctx, updatedRayService); err != nil {
+				reqLogger.Error(err, "Failed to update RayService")
 				return ctrl.Result{}, err
 			}
+			rayService = updatedRayService
 		}
 
-		// Update Status with RayCluster information
-		r.updateRayClusterStatus(ctx, genAIDeployment, rayCluster)
+		// Update Status with RayService information
+		r.updateRayServiceStatus(ctx, genAIDeployment, rayService)
+
+		readyChecker := statuscheck.NewReadyChecker(r.Client)
+		rayReady, err := readyChecker.IsReady(ctx, rayService)
+		if err != nil {
+			reqLogger.Error(err, "Failed to check RayService readiness")
+			return ctrl.Result{}, err
+		}
+		if !rayReady {
+			r.setCondition(ctx, genAIDeployment, reasonRayClusterNotReady, "Waiting for RayService active cluster to become ready")
+			return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+		}
 	}
 
 	// Reconcile SaisService Deployment
-	if err := r.reconcileSaisServiceDeployment(ctx, genAIDeployment); err != nil {
+	saisReady, err := r.reconcileSaisServiceDeployment(ctx, genAIDeployment, scheduler, modelCachePVCName)
+	if err != nil {
 		reqLogger.Error(err, "Failed to reconcile SaisService Deployment")
 		return ctrl.Result{}, err
 	}
+	if !saisReady {
+		r.setCondition(ctx, genAIDeployment, reasonSaisNotReady, "Waiting for SaisService Deployment rollout to finish")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
 
 	// Reconcile VectorDb Deployment
-	if err := r.reconcileVectorDbDeployment(ctx, genAIDeployment); err != nil {
+	vectorDBReady, err := r.reconcileVectorDbDeployment(ctx, genAIDeployment, scheduler)
+	if err != nil {
 		reqLogger.Error(err, "Failed to reconcile VectorDb Deployment")
 		return ctrl.Result{}, err
 	}
+	if !vectorDBReady {
+		r.setCondition(ctx, genAIDeployment, reasonVectorDBIndexing, "Waiting for VectorDb backend index to become ready")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *GenAIDeploymentReconciler) constructRayCluster(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment) *rayv1.RayCluster {
-	// Create RayCluster object based on GenAIDeployment spec
-	return &rayv1.RayCluster{
+// setCondition records why the reconcile is about to requeue instead of
+// finishing, surfacing it on GenAIDeployment.Status.Conditions.
+func (r *GenAIDeploymentReconciler) setCondition(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, reason, message string) {
+	reqLogger := log.FromContext(ctx)
+
+	meta.SetStatusCondition(&genAIDeployment.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Client.Status().Update(ctx, genAIDeployment); err != nil {
+		reqLogger.Error(err, "Failed to update GenAIDeployment conditions")
+	}
+}
+
+// gpuResourceName returns the extended resource key a vendor's device plugin
+// advertises GPUs under.
+func gpuResourceName(vendor string) corev1.ResourceName {
+	switch vendor {
+	case gpuVendorAMD:
+		return "amd.com/gpu"
+	case gpuVendorIntel:
+		return "gpu.intel.com/i915"
+	default:
+		return "nvidia.com/gpu"
+	}
+}
+
+// gpuRuntimeClassName returns the RuntimeClass a vendor's pods must opt into for
+// its device plugin to inject the accelerator runtime.
+func gpuRuntimeClassName(vendor string) string {
+	switch vendor {
+	case gpuVendorAMD:
+		return "amdgpu"
+	case gpuVendorIntel:
+		return "intel-gpu"
+	default:
+		return "nvidia"
+	}
+}
+
+// applyGPUScheduling translates a GPU block into the nodeSelector, resource
+// limit, RuntimeClassName, and toleration a pod needs to actually land on and use
+// the requested accelerator, so callers don't have to hand-roll this translation
+// at every construct* call site. It is a no-op when gpu is nil or requests zero
+// GPUs.
+func applyGPUScheduling(podSpec *corev1.PodSpec, gpu *enterpriseApi.GPUSpec, containerIndex int) {
+	if gpu == nil || gpu.Count == 0 {
+		return
+	}
+
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	if gpu.Product != "" {
+		podSpec.NodeSelector["nvidia.com/gpu.product"] = gpu.Product
+	}
+	if gpu.MIGProfile != "" {
+		podSpec.NodeSelector["nvidia.com/mig.profile"] = gpu.MIGProfile
+	}
+
+	resourceName := gpuResourceName(gpu.Vendor)
+	container := &podSpec.Containers[containerIndex]
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	container.Resources.Limits[resourceName] = *resource.NewQuantity(int64(gpu.Count), resource.DecimalSI)
+
+	runtimeClassName := gpuRuntimeClassName(gpu.Vendor)
+	podSpec.RuntimeClassName = &runtimeClassName
+
+	podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+		Key:      string(resourceName),
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	})
+}
+
+// modelCacheSyncCommand returns the init-container command that warms the model
+// cache PVC from Spec.ModelCache.SourceURI, picking the CLI that matches the
+// URI's scheme: the AWS CLI for s3:// and rclone (via a preconfigured GCS remote
+// named "gcs") for gs://.
+func modelCacheSyncCommand(sourceURI string) []string {
+	if strings.HasPrefix(sourceURI, "gs://") {
+		return []string{"rclone", "sync", "gcs:" + strings.TrimPrefix(sourceURI, "gs://"), modelCacheMountPath}
+	}
+	return []string{"aws", "s3", "sync", sourceURI, modelCacheMountPath}
+}
+
+// applyModelCache wires the shared model cache into podSpec when cache is
+// configured: an init container that warms pvcName on first attach, and a
+// read-only mount of it into the given containers. pvcName is ignored when
+// cache.HostPathCache is set, since that toggle dedupes downloads per-node via a
+// hostPath volume instead of a shared ReadOnlyMany PVC.
+func applyModelCache(podSpec *corev1.PodSpec, cache *enterpriseApi.ModelCacheSpec, pvcName string, containerIndices ...int) {
+	if cache == nil || cache.SourceURI == "" {
+		return
+	}
+
+	volume := corev1.Volume{Name: modelCacheVolumeName}
+	if cache.HostPathCache {
+		hostPathDirectory := corev1.HostPathDirectoryOrCreate
+		volume.HostPath = &corev1.HostPathVolumeSource{
+			Path: "/var/cache/splunk-genai/model-cache",
+			Type: &hostPathDirectory,
+		}
+	} else {
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: pvcName,
+		}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:    "model-cache-sync",
+		Image:   cache.SyncImage,
+		Command: modelCacheSyncCommand(cache.SourceURI),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: modelCacheVolumeName, MountPath: modelCacheMountPath},
+		},
+	})
+
+	mount := corev1.VolumeMount{Name: modelCacheVolumeName, MountPath: modelCacheMountPath, ReadOnly: true}
+	for _, idx := range containerIndices {
+		podSpec.Containers[idx].VolumeMounts = append(podSpec.Containers[idx].VolumeMounts, mount)
+	}
+}
+
+// reconcileModelCachePVC creates or updates the shared PersistentVolumeClaim that
+// backs Spec.ModelCache, returning its name for callers to mount by reference. It
+// is a no-op -- and returns an empty name -- when ModelCache is unset or opts
+// into the per-node hostPath cache instead of a shared PVC.
+func (r *GenAIDeploymentReconciler) reconcileModelCachePVC(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment) (string, error) {
+	cache := genAIDeployment.Spec.ModelCache
+	if cache == nil || cache.SourceURI == "" || cache.HostPathCache {
+		return "", nil
+	}
+
+	pvcName := fmt.Sprintf("%s-model-cache", genAIDeployment.Name)
+	desired := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      genAIDeployment.Name + "-raycluster",
+			Name:      pvcName,
 			Namespace: genAIDeployment.Namespace,
 		},
-		Spec: rayv1.RayClusterSpec{
-			HeadGroupSpec: rayv1.HeadGroupSpec{
-				RayStartParams: map[string]string{
-					"num-cpus": genAIDeployment.Spec.RayService.HeadGroup.NumCpus,
-				},
-				Template: corev1.PodTemplateSpec{
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:      "ray-head",
-								Image:     genAIDeployment.Spec.RayService.Image,
-								Resources: genAIDeployment.Spec.RayService.HeadGroup.Resources,
-							},
-						},
-					},
+		Spec: cache.VolumeClaimTemplate,
+	}
+	ctrl.SetControllerReference(genAIDeployment, desired, r.Scheme)
+
+	existing := &corev1.PersistentVolumeClaim{}
+	if _, err := util.ApplyWithMerge(ctx, r.Client, r.Recorder, genAIDeployment, desired, existing); err != nil {
+		return "", fmt.Errorf("failed to reconcile model cache PVC: %w", err)
+	}
+
+	// Best-effort: a single non-blocking check, logged rather than gated on, since
+	// WaitForFirstConsumer storage classes only bind once a consuming pod is
+	// scheduled -- retrying WaitForResources here would stall every reconcile for
+	// exactly the PVCs that structurally can't bind until the pods we're about to
+	// construct with this very PVC name get scheduled.
+	readyChecker := statuscheck.NewReadyChecker(r.Client)
+	if ready, err := readyChecker.IsReady(ctx, existing); err != nil {
+		log.FromContext(ctx).Info("Failed to check model cache PVC readiness", "error", err.Error())
+	} else if !ready {
+		log.FromContext(ctx).Info("Model cache PVC not yet bound")
+	}
+	return pvcName, nil
+}
+
+// constructRayClusterSpec builds the embedded RayClusterSpec that RayService uses to
+// stand up (and, on upgrade, stand up again alongside) the underlying RayCluster.
+func (r *GenAIDeploymentReconciler) constructRayClusterSpec(genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, modelCachePVCName string) rayv1.RayClusterSpec {
+	rayServiceSpec := genAIDeployment.Spec.RayService
+
+	headRayStartParams := map[string]string{
+		"num-cpus": rayServiceSpec.HeadGroup.NumCpus,
+	}
+	headTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:      "ray-head",
+					Image:     rayServiceSpec.Image,
+					Resources: rayServiceSpec.HeadGroup.Resources,
 				},
 			},
-			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
-				{
-					GroupName: "ray-worker",
-					Replicas:  &genAIDeployment.Spec.RayService.Replicas,
-					Template: corev1.PodTemplateSpec{
-						Spec: corev1.PodSpec{
-							Containers: []corev1.Container{
-								{
-									Name:      "ray-worker",
-									Image:     genAIDeployment.Spec.RayService.Image,
-									Resources: genAIDeployment.Spec.RayService.WorkerGroup.Resources,
-								},
-							},
-						},
+		},
+	}
+	scheduler.AddMetadataToPod(genAIDeployment, "ray", &headTemplate)
+
+	workerGroupSpecs := make([]rayv1.WorkerGroupSpec, 0, len(rayServiceSpec.WorkerGroupSpecs))
+	for _, wg := range rayServiceSpec.WorkerGroupSpecs {
+		wg := wg // capture per-iteration copy; Replicas/MinReplicas/MaxReplicas are taken by address below
+		workerRayStartParams := map[string]string{
+			"num-cpus": wg.RayStartParams["num-cpus"],
+		}
+		workerTemplate := corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:      "ray-worker",
+						Image:     rayServiceSpec.Image,
+						Resources: wg.Resources,
 					},
 				},
 			},
+		}
+		applyGPUScheduling(&workerTemplate.Spec, wg.GPU, 0)
+		applyModelCache(&workerTemplate.Spec, genAIDeployment.Spec.ModelCache, modelCachePVCName, 0)
+		scheduler.AddMetadataToPod(genAIDeployment, "ray", &workerTemplate)
+
+		workerGroupSpecs = append(workerGroupSpecs, rayv1.WorkerGroupSpec{
+			GroupName:      wg.GroupName,
+			Replicas:       &wg.Replicas,
+			MinReplicas:    &wg.MinReplicas,
+			MaxReplicas:    &wg.MaxReplicas,
+			RayStartParams: workerRayStartParams,
+			Template:       workerTemplate,
+		})
+	}
+
+	return rayv1.RayClusterSpec{
+		EnableInTreeAutoscaling: &rayServiceSpec.EnableInTreeAutoscaling,
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			RayStartParams: headRayStartParams,
+			Template:       headTemplate,
 		},
+		WorkerGroupSpecs: workerGroupSpecs,
 	}
 }
 
-func (r *GenAIDeploymentReconciler) updateRayClusterStatus(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, rayCluster *rayv1.RayCluster) {
+// constructRayService builds the RayService that owns the Ray Serve application
+// described by GenAIDeployment.Spec.RayService, delegating cluster lifecycle and
+// zero-downtime upgrades (in-place vs. blue-green) to KubeRay.
+func (r *GenAIDeploymentReconciler) constructRayService(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, modelCachePVCName string) *rayv1.RayService {
+	rayServiceSpec := genAIDeployment.Spec.RayService
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      genAIDeployment.Name + "-rayservice",
+			Namespace: genAIDeployment.Namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2:   rayServiceSpec.ServeConfigV2,
+			UpgradeStrategy: &rayServiceSpec.UpgradeStrategy,
+			RayClusterSpec:  r.constructRayClusterSpec(genAIDeployment, scheduler, modelCachePVCName),
+		},
+	}
+
+	ctrl.SetControllerReference(genAIDeployment, rayService, r.Scheme)
+	return rayService
+}
+
+// updateRayServiceStatus mirrors the RayService's active/pending cluster state and
+// Serve application statuses onto GenAIDeployment.Status so callers don't need to
+// read the KubeRay CRD directly.
+func (r *GenAIDeploymentReconciler) updateRayServiceStatus(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, rayService *rayv1.RayService) {
 	reqLogger := log.FromContext(ctx)
-	reqLogger = reqLogger.WithValues("updateRayClusterStatus")
+	reqLogger = reqLogger.WithValues("updateRayServiceStatus")
 
-	// Fetch RayCluster status and update GenAIDeployment status
 	genAIDeployment.Status.RayClusterStatus = enterpriseApi.RayClusterStatus{
-		ClusterName: rayCluster.Name,
-		State:       string(rayCluster.Status.State),
-		Conditions:  rayCluster.Status.Conditions,
+		ClusterName: rayService.Status.ActiveServiceStatus.RayClusterName,
+		State:       string(rayService.Status.ActiveServiceStatus.RayClusterStatus.State),
+		Conditions:  rayService.Status.ActiveServiceStatus.RayClusterStatus.Conditions,
+	}
+	genAIDeployment.Status.ServeStatus = enterpriseApi.ServeStatus{
+		ActiveClusterName:        rayService.Status.ActiveServiceStatus.RayClusterName,
+		PendingClusterName:       rayService.Status.PendingServiceStatus.RayClusterName,
+		ServeApplicationStatuses: rayService.Status.ServeApplicationStatuses,
 	}
+
 	err := r.Client.Status().Update(context.Background(), genAIDeployment)
 	if err != nil {
 		reqLogger.Error(err, "Failed to update GenAIDeployment status")
 	}
 }
 
-func (r *GenAIDeploymentReconciler) updateRayCluster(ctx context.Context, existingCluster *rayv1.RayCluster, genAIDeployment *enterpriseApi.GenAIDeployment) *rayv1.RayCluster {
-	// Update RayCluster spec if necessary
-	// ...
-	return existingCluster
+func (r *GenAIDeploymentReconciler) updateRayService(ctx context.Context, existingRayService *rayv1.RayService, genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, modelCachePVCName string) *rayv1.RayService {
+	rayServiceSpec := genAIDeployment.Spec.RayService
+
+	existingRayService.Spec.ServeConfigV2 = rayServiceSpec.ServeConfigV2
+	existingRayService.Spec.UpgradeStrategy = &rayServiceSpec.UpgradeStrategy
+	existingRayService.Spec.RayClusterSpec = r.constructRayClusterSpec(genAIDeployment, scheduler, modelCachePVCName)
+	return existingRayService
 }
 
 func (r *GenAIDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&enterpriseApi.GenAIDeployment{}).
-		Owns(&rayv1.RayCluster{}).
+		Owns(&rayv1.RayService{}).
 		Complete(r)
 }
 
-func (r *GenAIDeploymentReconciler) reconcileSaisServiceDeployment(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment) error {
+// reconcileSaisServiceDeployment reconciles the SaisService Deployment and reports
+// whether it has finished rolling out, so the caller can gate on real readiness
+// instead of treating a successful apply as done.
+func (r *GenAIDeploymentReconciler) reconcileSaisServiceDeployment(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, modelCachePVCName string) (bool, error) {
 	log := log.FromContext(ctx)
 
-	// Define the desired Deployment object
-	desiredDeployment := r.constructSaisServiceDeployment(genAIDeployment)
-
-	// Check if the Deployment already exists
+	desiredDeployment := r.constructSaisServiceDeployment(genAIDeployment, scheduler, modelCachePVCName)
 	existingDeployment := &appsv1.Deployment{}
-	err := r.Get(ctx, client.ObjectKey{Name: desiredDeployment.Name, Namespace: desiredDeployment.Namespace}, existingDeployment)
-	if err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			return err
-		}
 
-		// Create the Deployment if it does not exist
-		log.Info("Creating new Deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
-		if err := r.Create(ctx, desiredDeployment); err != nil {
-			return fmt.Errorf("failed to create new Deployment: %w", err)
-		}
-	} else {
-		// Update the existing Deployment if necessary
-		if !isEqual(desiredDeployment, existingDeployment) {
-			log.Info("Updating existing Deployment", "Deployment.Namespace", existingDeployment.Namespace, "Deployment.Name", existingDeployment.Name)
-			existingDeployment.Spec = desiredDeployment.Spec
-			if err := r.Update(ctx, existingDeployment); err != nil {
-				return fmt.Errorf("failed to update Deployment: %w", err)
-			}
-		}
+	changed, err := util.ApplyWithMerge(ctx, r.Client, r.Recorder, genAIDeployment, desiredDeployment, existingDeployment)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply SaisService Deployment: %w", err)
+	}
+	if changed {
+		log.Info("Applied SaisService Deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
+		return false, nil
 	}
 
-	return nil
+	readyChecker := statuscheck.NewReadyChecker(r.Client)
+	if err := readyChecker.WaitForResources(ctx, readinessPollTimeout, []client.Object{existingDeployment}); err != nil {
+		log.Info("SaisService Deployment not yet ready", "error", err.Error())
+		return false, nil
+	}
+	return true, nil
 }
 
-func (r *GenAIDeploymentReconciler) constructSaisServiceDeployment(genAIDeployment *enterpriseApi.GenAIDeployment) *appsv1.Deployment {
+func (r *GenAIDeploymentReconciler) constructSaisServiceDeployment(genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, modelCachePVCName string) *appsv1.Deployment {
 	labels := map[string]string{
 		"app":        "sais-service",
 		"deployment": genAIDeployment.Name,
@@ -248,97 +551,48 @@ func (r *GenAIDeploymentReconciler) constructSaisServiceDeployment(genAIDeployme
 		},
 	}
 
+	applyGPUScheduling(&deployment.Spec.Template.Spec, genAIDeployment.Spec.SaisService.GPU, 0)
+	applyModelCache(&deployment.Spec.Template.Spec, genAIDeployment.Spec.ModelCache, modelCachePVCName, 0)
+	scheduler.AddMetadataToPod(genAIDeployment, "sais", &deployment.Spec.Template)
+
 	// Set the owner reference to enable garbage collection
 	ctrl.SetControllerReference(genAIDeployment, deployment, r.Scheme)
 	return deployment
 }
 
-func isEqual(desired, existing *appsv1.Deployment) bool {
-	// Compare important fields for determining if an update is necessary
-	// This is a simplified example; you may need a more thorough comparison
-	return desired.Spec.Replicas == existing.Spec.Replicas &&
-		desired.Spec.Template.Spec.Containers[0].Image == existing.Spec.Template.Spec.Containers[0].Image
-}
-
-func (r *GenAIDeploymentReconciler) reconcileVectorDbDeployment(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment) error {
-	log := log.FromContext(ctx)
-
-	// Define the desired Deployment object for the VectorDb service
-	desiredDeployment := r.constructVectorDbDeployment(genAIDeployment)
+// reconcileVectorDbDeployment dispatches to the vectordb.Backend selected by
+// GenAIDeployment.Spec.VectorDbService.Type, records what it reports back onto
+// GenAIDeployment.Status.VectorDbStatus, and reports whether the backend's
+// underlying resources (StatefulSets, including Milvus's etcd/minio
+// dependencies) have actually finished rolling out, per statuscheck.ReadyChecker
+// -- not just whether Status.ReadyReplicas happens to already match desired.
+func (r *GenAIDeploymentReconciler) reconcileVectorDbDeployment(ctx context.Context, genAIDeployment *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler) (bool, error) {
+	reqLogger := log.FromContext(ctx)
 
-	// Check if the Deployment already exists
-	existingDeployment := &appsv1.Deployment{}
-	err := r.Get(ctx, client.ObjectKey{Name: desiredDeployment.Name, Namespace: desiredDeployment.Namespace}, existingDeployment)
+	backend, err := vectordb.New(genAIDeployment.Spec.VectorDbService.Type, r.Client, r.Scheme, scheduler, r.Recorder)
 	if err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			return err
-		}
-
-		// Create the Deployment if it does not exist
-		log.Info("Creating new VectorDb Deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
-		if err := r.Create(ctx, desiredDeployment); err != nil {
-			return fmt.Errorf("failed to create new VectorDb Deployment: %w", err)
-		}
-	} else {
-		// Update the existing Deployment if necessary
-		if !isEqual(desiredDeployment, existingDeployment) {
-			log.Info("Updating existing VectorDb Deployment", "Deployment.Namespace", existingDeployment.Namespace, "Deployment.Name", existingDeployment.Name)
-			existingDeployment.Spec = desiredDeployment.Spec
-			if err := r.Update(ctx, existingDeployment); err != nil {
-				return fmt.Errorf("failed to update VectorDb Deployment: %w", err)
-			}
-		}
+		return false, fmt.Errorf("failed to select vector db backend: %w", err)
 	}
 
-	return nil
-}
-
-func (r *GenAIDeploymentReconciler) constructVectorDbDeployment(genAIDeployment *enterpriseApi.GenAIDeployment) *appsv1.Deployment {
-	labels := map[string]string{
-		"app":        "vectordb-service",
-		"deployment": genAIDeployment.Name,
+	reqLogger.Info("Reconciling VectorDb backend", "Type", genAIDeployment.Spec.VectorDbService.Type)
+	status, err := backend.Reconcile(ctx, genAIDeployment)
+	if err != nil {
+		return false, fmt.Errorf("failed to reconcile vector db backend: %w", err)
 	}
 
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-vectordb-service", genAIDeployment.Name),
-			Namespace: genAIDeployment.Namespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &genAIDeployment.Spec.VectorDbService.Replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:      "vectordb-container",
-							Image:     genAIDeployment.Spec.VectorDbService.Image,
-							Resources: genAIDeployment.Spec.VectorDbService.Resources,
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      genAIDeployment.Spec.VectorDbService.Volume.Name,
-									MountPath: "/data", // Adjust mount path as necessary
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						genAIDeployment.Spec.VectorDbService.Volume,
-					},
-					Affinity:                  &genAIDeployment.Spec.VectorDbService.Affinity,
-					Tolerations:               genAIDeployment.Spec.VectorDbService.Tolerations,
-					TopologySpreadConstraints: genAIDeployment.Spec.VectorDbService.TopologySpreadConstraints,
-				},
-			},
-		},
+	genAIDeployment.Status.VectorDbStatus = enterpriseApi.VectorDbStatus{
+		ConnectionURI: status.ConnectionURI,
+		ReadyReplicas: status.ReadyReplicas,
+		IndexState:    string(status.IndexState),
+	}
+	if err := r.Client.Status().Update(ctx, genAIDeployment); err != nil {
+		reqLogger.Error(err, "Failed to update GenAIDeployment VectorDb status")
 	}
 
-	// Set the owner reference to enable garbage collection
-	ctrl.SetControllerReference(genAIDeployment, deployment, r.Scheme)
-	return deployment
+	readyChecker := statuscheck.NewReadyChecker(r.Client)
+	if err := readyChecker.WaitForResources(ctx, readinessPollTimeout, backend.Objects(genAIDeployment)); err != nil {
+		reqLogger.Info("VectorDb backend not yet ready", "error", err.Error())
+		return false, nil
+	}
+	return true, nil
 }
@@ -0,0 +1,96 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batchscheduler lets the GenAIDeployment controller hand gang
+// scheduling for a deployment's Ray head/worker and SaisService pods off to a
+// batch scheduler, following the same plugin shape KubeRay uses for Volcano:
+// a scheduler is asked to size and submit a scheduling unit once per
+// reconcile, and to stamp its own annotations onto every pod template the
+// controller builds before that template is ever sent to the API server.
+package batchscheduler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+)
+
+// DefaultSchedulerName is the value of Spec.BatchScheduler that leaves pods
+// ungrouped, i.e. scheduled independently by the default Kubernetes scheduler.
+const DefaultSchedulerName = "default"
+
+// VolcanoSchedulerName is the value of Spec.BatchScheduler that gang-schedules
+// a GenAIDeployment's pods through Volcano.
+const VolcanoSchedulerName = "volcano"
+
+// BatchScheduler gang-schedules the pods belonging to one GenAIDeployment.
+type BatchScheduler interface {
+	// Name returns the scheduler's identifier, matching the Spec.BatchScheduler value.
+	Name() string
+	// DoBatchSchedulingOnSubmission creates or updates whatever scheduling-unit
+	// object (e.g. a Volcano PodGroup) the scheduler needs sized for cr's current
+	// Ray/SaisService replica counts.
+	DoBatchSchedulingOnSubmission(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error
+	// AddMetadataToPod stamps the scheduler's own labels/annotations onto pod so
+	// the Kubernetes scheduler routes it to this scheduler and groups it with the
+	// rest of cr's gang-scheduled pods. groupName names the component the pod
+	// belongs to ("ray", "sais", "vectordb"). Called on every PodTemplateSpec the
+	// controller builds before Create/Update.
+	AddMetadataToPod(cr *enterpriseApi.GenAIDeployment, groupName string, pod *corev1.PodTemplateSpec)
+}
+
+// defaultScheduler is the no-op BatchScheduler used when Spec.BatchScheduler is
+// unset or "default" - it leaves pod scheduling entirely to Kubernetes.
+type defaultScheduler struct{}
+
+func (defaultScheduler) Name() string { return DefaultSchedulerName }
+
+func (defaultScheduler) DoBatchSchedulingOnSubmission(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return nil
+}
+
+func (defaultScheduler) AddMetadataToPod(cr *enterpriseApi.GenAIDeployment, groupName string, pod *corev1.PodTemplateSpec) {
+}
+
+// SchedulerManager resolves the BatchScheduler named by a GenAIDeployment's
+// Spec.BatchScheduler field.
+type SchedulerManager struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// NewSchedulerManager returns a SchedulerManager that creates scheduling-unit
+// objects through c.
+func NewSchedulerManager(c client.Client, scheme *runtime.Scheme) *SchedulerManager {
+	return &SchedulerManager{Client: c, Scheme: scheme}
+}
+
+// GetScheduler returns the BatchScheduler named by cr.Spec.BatchScheduler.
+func (m *SchedulerManager) GetScheduler(cr *enterpriseApi.GenAIDeployment) (BatchScheduler, error) {
+	switch cr.Spec.BatchScheduler {
+	case "", DefaultSchedulerName:
+		return defaultScheduler{}, nil
+	case VolcanoSchedulerName:
+		return &VolcanoScheduler{Client: m.Client, Scheme: m.Scheme}, nil
+	default:
+		return nil, fmt.Errorf("unsupported batch scheduler: %q", cr.Spec.BatchScheduler)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchscheduler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volcanov1beta1 "github.com/volcano-sh/apis/pkg/apis/scheduling/v1beta1"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+)
+
+// groupNameAnnotation and queueNameAnnotation are the pod-level annotations
+// Volcano's scheduler extender reads to decide gang membership and queue.
+const (
+	groupNameAnnotation = "scheduling.k8s.io/group-name"
+	queueNameAnnotation = "scheduler.volcano.sh/queue-name"
+)
+
+// VolcanoScheduler gang-schedules a GenAIDeployment's Ray head/worker and
+// SaisService pods through a single Volcano PodGroup, so they come up together
+// or not at all.
+type VolcanoScheduler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Name returns "volcano".
+func (s *VolcanoScheduler) Name() string { return VolcanoSchedulerName }
+
+// DoBatchSchedulingOnSubmission creates or updates the PodGroup sized to
+// min(rayHead) + min(workerReplicas) + saisReplicas, one PodGroup per
+// GenAIDeployment.
+func (s *VolcanoScheduler) DoBatchSchedulingOnSubmission(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	desired := s.constructPodGroup(cr)
+
+	existing := &volcanov1beta1.PodGroup{}
+	err := s.Get(ctx, client.ObjectKey{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err := s.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create volcano podgroup: %w", err)
+		}
+		return nil
+	}
+
+	existing.Spec = desired.Spec
+	if err := s.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update volcano podgroup: %w", err)
+	}
+	return nil
+}
+
+// AddMetadataToPod stamps the group-name and queue-name annotations Volcano
+// needs to gang-schedule pod in the GenAIDeployment's single PodGroup, and sets
+// its schedulerName to volcano. groupName is not used: every pod of cr joins the
+// same PodGroup.
+func (s *VolcanoScheduler) AddMetadataToPod(cr *enterpriseApi.GenAIDeployment, groupName string, pod *corev1.PodTemplateSpec) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[groupNameAnnotation] = podGroupName(cr)
+	pod.Annotations[queueNameAnnotation] = cr.Spec.VolcanoQueue
+	pod.Spec.SchedulerName = "volcano"
+}
+
+// podGroupName returns the name of the single PodGroup shared by all of a
+// GenAIDeployment's gang-scheduled pods.
+func podGroupName(cr *enterpriseApi.GenAIDeployment) string {
+	return cr.Name + "-podgroup"
+}
+
+// minWorkerReplicas sums the minimum member count each worker group needs to be
+// considered viable, falling back to its steady-state replica count when no
+// autoscaling floor is set.
+func minWorkerReplicas(rayService enterpriseApi.RayServiceSpec) int32 {
+	var total int32
+	for _, wg := range rayService.WorkerGroupSpecs {
+		if wg.MinReplicas > 0 {
+			total += wg.MinReplicas
+		} else {
+			total += wg.Replicas
+		}
+	}
+	return total
+}
+
+func (s *VolcanoScheduler) constructPodGroup(cr *enterpriseApi.GenAIDeployment) *volcanov1beta1.PodGroup {
+	minMember := int32(0)
+	if cr.Spec.RayService.Enabled {
+		minMember += 1 // ray head
+		minMember += minWorkerReplicas(cr.Spec.RayService)
+	}
+	minMember += cr.Spec.SaisService.Replicas
+
+	podGroup := &volcanov1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podGroupName(cr),
+			Namespace: cr.Namespace,
+		},
+		Spec: volcanov1beta1.PodGroupSpec{
+			MinMember: minMember,
+			Queue:     cr.Spec.VolcanoQueue,
+		},
+	}
+	ctrl.SetControllerReference(cr, podGroup, s.Scheme)
+
+	return podGroup
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vectordb provides a pluggable backend abstraction for the vector
+// database that backs a GenAIDeployment's retrieval pipeline. Each supported
+// engine (Milvus, Weaviate, Qdrant, pgvector) implements Backend so that the
+// GenAIDeployment controller can reconcile whichever one the CR asks for
+// without branching on engine-specific logic itself.
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+)
+
+// IndexState describes where a backend's vector index is in its build lifecycle.
+type IndexState string
+
+const (
+	// IndexStatePending means no backend replica is ready yet, so the index has not started building.
+	IndexStatePending IndexState = "Pending"
+	// IndexStateBuilding means the backend is actively building its index.
+	IndexStateBuilding IndexState = "Building"
+	// IndexStateReady means the index is built and queryable.
+	IndexStateReady IndexState = "Ready"
+)
+
+// Status is returned by Reconcile and mirrors into GenAIDeployment.Status.VectorDbStatus.
+type Status struct {
+	// ConnectionURI is the in-cluster address clients should use to reach the backend.
+	ConnectionURI string
+	// ReadyReplicas is the number of backend replicas that are currently ready.
+	ReadyReplicas int32
+	// IndexState reports the build state of the configured collection/class index.
+	IndexState IndexState
+}
+
+// SchemaInfo describes the collection/class a backend exposes for the configured
+// GenAIDeployment, as set on GenAIDeployment.Spec.VectorDbService.
+type SchemaInfo struct {
+	// Name is the collection, class, or table name in the backend's own terminology.
+	Name string
+	// Dimensions is the vector dimensionality the schema was created with.
+	Dimensions int32
+	// DistanceMetric is the similarity metric the index was built with (e.g. "cosine", "l2", "ip").
+	DistanceMetric string
+	// IndexType is the backend-specific index algorithm (e.g. "HNSW", "IVF_FLAT").
+	IndexType string
+}
+
+// Backend reconciles the Kubernetes resources for one vector database engine and
+// reports its readiness and schema back to the caller.
+type Backend interface {
+	// Reconcile creates or updates the backend's StatefulSets, whose PVCs come from
+	// their volumeClaimTemplates, returning its current Status. It creates no
+	// Service or Deployment.
+	Reconcile(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (Status, error)
+	// Schema returns the collection/class/table configured for the backend on cr's
+	// spec; the backend itself is not queried.
+	Schema(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (SchemaInfo, error)
+	// HealthCheck returns an error unless the backend's StatefulSet has at least
+	// one ready replica; it does not send a query to the backend.
+	HealthCheck(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error
+	// Objects returns bare references to every resource Reconcile manages for cr,
+	// for a caller to wait on with statuscheck.ReadyChecker.WaitForResources.
+	Objects(cr *enterpriseApi.GenAIDeployment) []client.Object
+}
+
+// New returns the Backend implementation for the given discriminator value, as set
+// on GenAIDeployment.Spec.VectorDbService.Type. scheduler is stamped onto every pod
+// template the backend builds before Create/Update, so the vector db's pods land in
+// the same gang as the rest of the GenAIDeployment's workload. recorder is threaded
+// through to each backend's use of util.ApplyWithMerge and may be nil.
+func New(backendType string, c client.Client, scheme *runtime.Scheme, scheduler batchscheduler.BatchScheduler, recorder record.EventRecorder) (Backend, error) {
+	switch backendType {
+	case "milvus":
+		return &MilvusBackend{Client: c, Scheme: scheme, Scheduler: scheduler, Recorder: recorder}, nil
+	case "weaviate":
+		return &WeaviateBackend{Client: c, Scheme: scheme, Scheduler: scheduler, Recorder: recorder}, nil
+	case "qdrant":
+		return &QdrantBackend{Client: c, Scheme: scheme, Scheduler: scheduler, Recorder: recorder}, nil
+	case "pgvector":
+		return &PgVectorBackend{Client: c, Scheme: scheme, Scheduler: scheduler, Recorder: recorder}, nil
+	default:
+		return nil, fmt.Errorf("unsupported vector db backend type: %q", backendType)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+	"github.com/splunk/splunk-operator/pkg/splunk/util"
+)
+
+// ctrlSetOwner sets cr as the owner of controlled so it's garbage-collected along
+// with the GenAIDeployment it belongs to.
+func ctrlSetOwner(cr *enterpriseApi.GenAIDeployment, controlled client.Object, scheme *runtime.Scheme) {
+	ctrl.SetControllerReference(cr, controlled, scheme)
+}
+
+// statefulSetRef is a bare object reference a caller can hand to
+// statuscheck.ReadyChecker, which re-Gets the object by name/namespace before
+// inspecting its status -- the backends don't need to populate anything else.
+func statefulSetRef(name, namespace string) client.Object {
+	return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+// singleContainerStatefulSetParams describes the single-container,
+// single-PVC StatefulSet shape Milvus, Weaviate, Qdrant, pgvector, and
+// Milvus's own etcd/minio dependencies all build.
+type singleContainerStatefulSetParams struct {
+	name            string
+	namespace       string
+	appLabel        string
+	deployment      string
+	image           string
+	replicas        int32
+	resources       corev1.ResourceRequirements
+	env             []corev1.EnvVar
+	volumeName      string
+	volumeMountPath string
+	volumeClaim     corev1.PersistentVolumeClaimSpec
+}
+
+// constructSingleContainerStatefulSet builds the StatefulSet described by p,
+// labeling its pod template and registering it with scheduler the same way
+// every single-container vectordb backend does.
+func constructSingleContainerStatefulSet(cr *enterpriseApi.GenAIDeployment, scheduler batchscheduler.BatchScheduler, scheme *runtime.Scheme, p singleContainerStatefulSetParams) *appsv1.StatefulSet {
+	labels := map[string]string{
+		"app":        p.appLabel,
+		"deployment": p.deployment,
+	}
+
+	var volumeMounts []corev1.VolumeMount
+	if p.volumeMountPath != "" {
+		volumeMounts = []corev1.VolumeMount{{Name: p.volumeName, MountPath: p.volumeMountPath}}
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.name,
+			Namespace: p.namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: p.name,
+			Replicas:    &p.replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:         p.appLabel,
+							Image:        p.image,
+							Resources:    p.resources,
+							Env:          p.env,
+							VolumeMounts: volumeMounts,
+						},
+					},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: p.volumeName},
+					Spec:       p.volumeClaim,
+				},
+			},
+		},
+	}
+
+	scheduler.AddMetadataToPod(cr, "vectordb", &sts.Spec.Template)
+	ctrlSetOwner(cr, sts, scheme)
+	return sts
+}
+
+// reconcileStatefulSet creates or updates desired via ApplyWithMerge and returns
+// the StatefulSet's current state, for callers that need Status.ReadyReplicas
+// (e.g. to derive an IndexState) without repeating the Get-into-existing dance.
+func reconcileStatefulSet(ctx context.Context, c client.Client, recorder record.EventRecorder, cr *enterpriseApi.GenAIDeployment, desired *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	existing := &appsv1.StatefulSet{}
+	if _, err := util.ApplyWithMerge(ctx, c, recorder, cr, desired, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// statefulSetHealthCheck reports an error unless the named StatefulSet has at
+// least one ready replica, the shared liveness bar every single-container
+// vectordb backend holds itself to. kind names the backend (e.g. "milvus") for
+// the error message; name/namespace identify the StatefulSet itself.
+func statefulSetHealthCheck(ctx context.Context, c client.Client, kind, name, namespace string) error {
+	sts := &appsv1.StatefulSet{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, sts); err != nil {
+		return fmt.Errorf("failed to get %s statefulset: %w", kind, err)
+	}
+	if sts.Status.ReadyReplicas < 1 {
+		return fmt.Errorf("%s statefulset %s has no ready replicas", kind, name)
+	}
+	return nil
+}
+
+// indexStateForReplicas is a coarse readiness-to-index-state mapping shared by the
+// stateful backends: no ready replicas means the index can't have started building,
+// a subset means it's still catching up, and fully ready means queries can land.
+func indexStateForReplicas(ready, desired int32) IndexState {
+	switch {
+	case ready <= 0:
+		return IndexStatePending
+	case ready < desired:
+		return IndexStateBuilding
+	default:
+		return IndexStateReady
+	}
+}
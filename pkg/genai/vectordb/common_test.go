@@ -0,0 +1,137 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/splunk/util"
+)
+
+// noOpScheduler is a BatchScheduler that leaves pod templates untouched, the
+// same role defaultScheduler plays in the real controller.
+type noOpScheduler struct{}
+
+func (noOpScheduler) Name() string { return "default" }
+func (noOpScheduler) DoBatchSchedulingOnSubmission(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return nil
+}
+func (noOpScheduler) AddMetadataToPod(cr *enterpriseApi.GenAIDeployment, groupName string, pod *corev1.PodTemplateSpec) {
+}
+
+func baseGenAIDeployment() *enterpriseApi.GenAIDeployment {
+	return &enterpriseApi.GenAIDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "genai",
+			Namespace: "default",
+		},
+	}
+}
+
+func baseStatefulSetParams() singleContainerStatefulSetParams {
+	return singleContainerStatefulSetParams{
+		name:       "genai-vectordb",
+		namespace:  "default",
+		appLabel:   "vectordb",
+		deployment: "genai",
+		image:      "vectordb:1.0",
+		replicas:   1,
+		resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+		env:         []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+		volumeName:  "vectordb-data",
+		volumeClaim: corev1.PersistentVolumeClaimSpec{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}}},
+	}
+}
+
+// applyParamsTwice builds a StatefulSet from p via constructSingleContainerStatefulSet,
+// applies it once to create, then re-applies after mutate, returning whether the
+// second call reported a change -- mirroring pkg/splunk/util's applyTwice but for the
+// StatefulSet shape every vectordb backend shares.
+func applyParamsTwice(t *testing.T, mutate func(*singleContainerStatefulSetParams)) bool {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to register appsv1 scheme: %v", err)
+	}
+	var c client.Client = fake.NewClientBuilder().WithScheme(scheme).Build()
+	cr := baseGenAIDeployment()
+
+	desired := constructSingleContainerStatefulSet(cr, noOpScheduler{}, scheme, baseStatefulSetParams())
+	existing := &appsv1.StatefulSet{}
+	if _, err := util.ApplyWithMerge(context.Background(), c, nil, cr, desired, existing); err != nil {
+		t.Fatalf("failed to create statefulset: %v", err)
+	}
+
+	p := baseStatefulSetParams()
+	mutate(&p)
+	desired = constructSingleContainerStatefulSet(cr, noOpScheduler{}, scheme, p)
+	existing = &appsv1.StatefulSet{}
+	changed, err := util.ApplyWithMerge(context.Background(), c, nil, cr, desired, existing)
+	if err != nil {
+		t.Fatalf("failed to reconcile mutated statefulset: %v", err)
+	}
+	return changed
+}
+
+// TestConstructSingleContainerStatefulSetDetectsFieldChanges exercises the
+// StatefulSet every vectordb backend builds, covering the VectorDbServiceSpec
+// fields (image, resources, replicas, env) each backend's Reconcile feeds into
+// singleContainerStatefulSetParams, plus the storage request on the
+// PersistentVolumeClaimSpec backends take from their own spec.
+func TestConstructSingleContainerStatefulSetDetectsFieldChanges(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*singleContainerStatefulSetParams)
+	}{
+		{"image", func(p *singleContainerStatefulSetParams) { p.image = "vectordb:2.0" }},
+		{"replicas", func(p *singleContainerStatefulSetParams) { p.replicas = 3 }},
+		{"resources", func(p *singleContainerStatefulSetParams) {
+			p.resources = corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}
+		}},
+		{"env", func(p *singleContainerStatefulSetParams) {
+			p.env = append(p.env, corev1.EnvVar{Name: "NEW_VAR", Value: "x"})
+		}},
+		{"volumeClaimStorage", func(p *singleContainerStatefulSetParams) {
+			p.volumeClaim = corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")}},
+			}
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if changed := applyParamsTwice(t, tc.mutate); !changed {
+				t.Errorf("expected ApplyWithMerge to report a change after mutating %s", tc.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+)
+
+// MilvusBackend reconciles a Milvus StatefulSet. Milvus itself depends on etcd for
+// metadata and either MinIO or an external S3-compatible endpoint for object
+// storage; when Spec.VectorDbService.Milvus.ExternalEndpoints is unset, this
+// backend also stands up the etcd and MinIO dependencies it needs.
+type MilvusBackend struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Scheduler batchscheduler.BatchScheduler
+	Recorder  record.EventRecorder
+}
+
+// Reconcile creates or updates the Milvus StatefulSet (and its etcd/MinIO
+// dependencies when no external endpoints are configured) for cr.
+func (b *MilvusBackend) Reconcile(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (Status, error) {
+	milvusSpec := cr.Spec.VectorDbService.Milvus
+
+	if milvusSpec.ExternalEndpoints.Etcd == "" {
+		if err := b.reconcileDependencyStatefulSet(ctx, cr, "etcd", milvusSpec.Etcd.Image, milvusSpec.Etcd.Replicas, "etcd-data", milvusSpec.Etcd.VolumeClaimTemplate); err != nil {
+			return Status{}, fmt.Errorf("failed to reconcile milvus etcd dependency: %w", err)
+		}
+	}
+	if milvusSpec.ExternalEndpoints.Minio == "" {
+		if err := b.reconcileDependencyStatefulSet(ctx, cr, "minio", milvusSpec.Minio.Image, milvusSpec.Minio.Replicas, "minio-data", milvusSpec.Minio.VolumeClaimTemplate); err != nil {
+			return Status{}, fmt.Errorf("failed to reconcile milvus minio dependency: %w", err)
+		}
+	}
+
+	name := fmt.Sprintf("%s-milvus", cr.Name)
+	desired := constructSingleContainerStatefulSet(cr, b.Scheduler, b.Scheme, singleContainerStatefulSetParams{
+		name:        name,
+		namespace:   cr.Namespace,
+		appLabel:    "milvus",
+		deployment:  cr.Name,
+		image:       milvusSpec.Image,
+		replicas:    milvusSpec.Replicas,
+		resources:   cr.Spec.VectorDbService.Resources,
+		volumeName:  "milvus-data",
+		volumeClaim: milvusSpec.VolumeClaimTemplate,
+	})
+
+	existing, err := reconcileStatefulSet(ctx, b.Client, b.Recorder, cr, desired)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to reconcile milvus statefulset: %w", err)
+	}
+
+	return Status{
+		ConnectionURI: fmt.Sprintf("%s.%s.svc.cluster.local:19530", desired.Name, desired.Namespace),
+		ReadyReplicas: existing.Status.ReadyReplicas,
+		IndexState:    indexStateForReplicas(existing.Status.ReadyReplicas, milvusSpec.Replicas),
+	}, nil
+}
+
+// Schema returns the collection Milvus was configured with for cr.
+func (b *MilvusBackend) Schema(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (SchemaInfo, error) {
+	milvusSpec := cr.Spec.VectorDbService.Milvus
+	return SchemaInfo{
+		Name:           milvusSpec.Collection,
+		Dimensions:     cr.Spec.VectorDbService.Dimensions,
+		DistanceMetric: cr.Spec.VectorDbService.DistanceMetric,
+		IndexType:      cr.Spec.VectorDbService.IndexType,
+	}, nil
+}
+
+// HealthCheck verifies the Milvus StatefulSet has at least one ready replica.
+func (b *MilvusBackend) HealthCheck(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return statefulSetHealthCheck(ctx, b.Client, "milvus", fmt.Sprintf("%s-milvus", cr.Name), cr.Namespace)
+}
+
+// Objects returns a reference to the Milvus StatefulSet, plus its etcd/minio
+// dependency StatefulSets when those aren't pointed at an external endpoint.
+func (b *MilvusBackend) Objects(cr *enterpriseApi.GenAIDeployment) []client.Object {
+	milvusSpec := cr.Spec.VectorDbService.Milvus
+	objs := []client.Object{statefulSetRef(fmt.Sprintf("%s-milvus", cr.Name), cr.Namespace)}
+	if milvusSpec.ExternalEndpoints.Etcd == "" {
+		objs = append(objs, statefulSetRef(fmt.Sprintf("%s-etcd", cr.Name), cr.Namespace))
+	}
+	if milvusSpec.ExternalEndpoints.Minio == "" {
+		objs = append(objs, statefulSetRef(fmt.Sprintf("%s-minio", cr.Name), cr.Namespace))
+	}
+	return objs
+}
+
+// reconcileDependencyStatefulSet creates or updates the single-container StatefulSet
+// used for Milvus's etcd and MinIO dependencies, giving it its own
+// VolumeClaimTemplate so pod restarts don't silently wipe the cluster metadata
+// (etcd) or object data (MinIO) Milvus relies on them for.
+func (b *MilvusBackend) reconcileDependencyStatefulSet(ctx context.Context, cr *enterpriseApi.GenAIDeployment, name, image string, replicas int32, volumeName string, volumeClaimTemplate corev1.PersistentVolumeClaimSpec) error {
+	desired := constructSingleContainerStatefulSet(cr, b.Scheduler, b.Scheme, singleContainerStatefulSetParams{
+		name:            fmt.Sprintf("%s-%s", cr.Name, name),
+		namespace:       cr.Namespace,
+		appLabel:        name,
+		deployment:      cr.Name,
+		image:           image,
+		replicas:        replicas,
+		volumeName:      volumeName,
+		volumeMountPath: "/data",
+		volumeClaim:     volumeClaimTemplate,
+	})
+
+	_, err := reconcileStatefulSet(ctx, b.Client, b.Recorder, cr, desired)
+	return err
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+)
+
+// PgVectorBackend reconciles a Postgres StatefulSet running with the pgvector
+// extension enabled, rather than a standalone purpose-built vector engine.
+type PgVectorBackend struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Scheduler batchscheduler.BatchScheduler
+	Recorder  record.EventRecorder
+}
+
+// Reconcile creates or updates the Postgres/pgvector StatefulSet for cr.
+func (b *PgVectorBackend) Reconcile(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (Status, error) {
+	pgvectorSpec := cr.Spec.VectorDbService.PgVector
+	name := fmt.Sprintf("%s-pgvector", cr.Name)
+	replicas := int32(1)
+
+	desired := constructSingleContainerStatefulSet(cr, b.Scheduler, b.Scheme, singleContainerStatefulSetParams{
+		name:       name,
+		namespace:  cr.Namespace,
+		appLabel:   "pgvector",
+		deployment: cr.Name,
+		image:      pgvectorSpec.Image,
+		replicas:   replicas,
+		resources:  cr.Spec.VectorDbService.Resources,
+		env: []corev1.EnvVar{
+			{Name: "POSTGRES_DB", Value: pgvectorSpec.Database},
+		},
+		volumeName:  "pgvector-data",
+		volumeClaim: pgvectorSpec.VolumeClaimTemplate,
+	})
+
+	existing, err := reconcileStatefulSet(ctx, b.Client, b.Recorder, cr, desired)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to reconcile pgvector statefulset: %w", err)
+	}
+
+	return Status{
+		ConnectionURI: fmt.Sprintf("postgres://%s.%s.svc.cluster.local:5432/%s", desired.Name, desired.Namespace, pgvectorSpec.Database),
+		ReadyReplicas: existing.Status.ReadyReplicas,
+		IndexState:    indexStateForReplicas(existing.Status.ReadyReplicas, replicas),
+	}, nil
+}
+
+// Schema returns the table pgvector was configured with for cr.
+func (b *PgVectorBackend) Schema(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (SchemaInfo, error) {
+	return SchemaInfo{
+		Name:           cr.Spec.VectorDbService.PgVector.Table,
+		Dimensions:     cr.Spec.VectorDbService.Dimensions,
+		DistanceMetric: cr.Spec.VectorDbService.DistanceMetric,
+		IndexType:      cr.Spec.VectorDbService.IndexType,
+	}, nil
+}
+
+// HealthCheck verifies the pgvector StatefulSet has at least one ready replica.
+func (b *PgVectorBackend) HealthCheck(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return statefulSetHealthCheck(ctx, b.Client, "pgvector", fmt.Sprintf("%s-pgvector", cr.Name), cr.Namespace)
+}
+
+// Objects returns a reference to the pgvector StatefulSet for cr.
+func (b *PgVectorBackend) Objects(cr *enterpriseApi.GenAIDeployment) []client.Object {
+	return []client.Object{statefulSetRef(fmt.Sprintf("%s-pgvector", cr.Name), cr.Namespace)}
+}
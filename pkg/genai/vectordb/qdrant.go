@@ -0,0 +1,86 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+)
+
+// QdrantBackend reconciles a Qdrant StatefulSet.
+type QdrantBackend struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Scheduler batchscheduler.BatchScheduler
+	Recorder  record.EventRecorder
+}
+
+// Reconcile creates or updates the Qdrant StatefulSet for cr.
+func (b *QdrantBackend) Reconcile(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (Status, error) {
+	qdrantSpec := cr.Spec.VectorDbService.Qdrant
+	name := fmt.Sprintf("%s-qdrant", cr.Name)
+
+	desired := constructSingleContainerStatefulSet(cr, b.Scheduler, b.Scheme, singleContainerStatefulSetParams{
+		name:        name,
+		namespace:   cr.Namespace,
+		appLabel:    "qdrant",
+		deployment:  cr.Name,
+		image:       qdrantSpec.Image,
+		replicas:    qdrantSpec.Replicas,
+		resources:   cr.Spec.VectorDbService.Resources,
+		volumeName:  "qdrant-data",
+		volumeClaim: qdrantSpec.VolumeClaimTemplate,
+	})
+
+	existing, err := reconcileStatefulSet(ctx, b.Client, b.Recorder, cr, desired)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to reconcile qdrant statefulset: %w", err)
+	}
+
+	return Status{
+		ConnectionURI: fmt.Sprintf("http://%s.%s.svc.cluster.local:6333", desired.Name, desired.Namespace),
+		ReadyReplicas: existing.Status.ReadyReplicas,
+		IndexState:    indexStateForReplicas(existing.Status.ReadyReplicas, qdrantSpec.Replicas),
+	}, nil
+}
+
+// Schema returns the collection Qdrant was configured with for cr.
+func (b *QdrantBackend) Schema(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (SchemaInfo, error) {
+	return SchemaInfo{
+		Name:           cr.Spec.VectorDbService.Qdrant.Collection,
+		Dimensions:     cr.Spec.VectorDbService.Dimensions,
+		DistanceMetric: cr.Spec.VectorDbService.DistanceMetric,
+		IndexType:      cr.Spec.VectorDbService.IndexType,
+	}, nil
+}
+
+// HealthCheck verifies the Qdrant StatefulSet has at least one ready replica.
+func (b *QdrantBackend) HealthCheck(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return statefulSetHealthCheck(ctx, b.Client, "qdrant", fmt.Sprintf("%s-qdrant", cr.Name), cr.Namespace)
+}
+
+// Objects returns a reference to the Qdrant StatefulSet for cr.
+func (b *QdrantBackend) Objects(cr *enterpriseApi.GenAIDeployment) []client.Object {
+	return []client.Object{statefulSetRef(fmt.Sprintf("%s-qdrant", cr.Name), cr.Namespace)}
+}
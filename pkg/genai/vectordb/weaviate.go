@@ -0,0 +1,87 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterpriseApi "github.com/splunk/splunk-operator/api/v4"
+	"github.com/splunk/splunk-operator/pkg/genai/batchscheduler"
+)
+
+// WeaviateBackend reconciles a Weaviate StatefulSet with the configured replica
+// count; it sets no Weaviate clustering configuration.
+type WeaviateBackend struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Scheduler batchscheduler.BatchScheduler
+	Recorder  record.EventRecorder
+}
+
+// Reconcile creates or updates the Weaviate StatefulSet for cr.
+func (b *WeaviateBackend) Reconcile(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (Status, error) {
+	weaviateSpec := cr.Spec.VectorDbService.Weaviate
+	name := fmt.Sprintf("%s-weaviate", cr.Name)
+
+	desired := constructSingleContainerStatefulSet(cr, b.Scheduler, b.Scheme, singleContainerStatefulSetParams{
+		name:        name,
+		namespace:   cr.Namespace,
+		appLabel:    "weaviate",
+		deployment:  cr.Name,
+		image:       weaviateSpec.Image,
+		replicas:    weaviateSpec.Replicas,
+		resources:   cr.Spec.VectorDbService.Resources,
+		volumeName:  "weaviate-data",
+		volumeClaim: weaviateSpec.VolumeClaimTemplate,
+	})
+
+	existing, err := reconcileStatefulSet(ctx, b.Client, b.Recorder, cr, desired)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to reconcile weaviate statefulset: %w", err)
+	}
+
+	return Status{
+		ConnectionURI: fmt.Sprintf("http://%s.%s.svc.cluster.local:8080", desired.Name, desired.Namespace),
+		ReadyReplicas: existing.Status.ReadyReplicas,
+		IndexState:    indexStateForReplicas(existing.Status.ReadyReplicas, weaviateSpec.Replicas),
+	}, nil
+}
+
+// Schema returns the class Weaviate was configured with for cr.
+func (b *WeaviateBackend) Schema(ctx context.Context, cr *enterpriseApi.GenAIDeployment) (SchemaInfo, error) {
+	return SchemaInfo{
+		Name:           cr.Spec.VectorDbService.Weaviate.ClassName,
+		Dimensions:     cr.Spec.VectorDbService.Dimensions,
+		DistanceMetric: cr.Spec.VectorDbService.DistanceMetric,
+		IndexType:      cr.Spec.VectorDbService.IndexType,
+	}, nil
+}
+
+// HealthCheck verifies the Weaviate StatefulSet has at least one ready replica.
+func (b *WeaviateBackend) HealthCheck(ctx context.Context, cr *enterpriseApi.GenAIDeployment) error {
+	return statefulSetHealthCheck(ctx, b.Client, "weaviate", fmt.Sprintf("%s-weaviate", cr.Name), cr.Namespace)
+}
+
+// Objects returns a reference to the Weaviate StatefulSet for cr.
+func (b *WeaviateBackend) Objects(cr *enterpriseApi.GenAIDeployment) []client.Object {
+	return []client.Object{statefulSetRef(fmt.Sprintf("%s-weaviate", cr.Name), cr.Namespace)}
+}
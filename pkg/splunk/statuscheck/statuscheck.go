@@ -0,0 +1,205 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statuscheck tells the GenAIDeployment controller whether a child
+// resource it just created or updated is actually ready to serve traffic,
+// modeled on Helm 3's kube.ReadyChecker. A Create/Update call returning
+// without error only means the API server accepted the object; it says
+// nothing about rollout progress, so callers that need real readiness check
+// through IsReady (RayService) or poll through WaitForResources (SaisService
+// Deployment, VectorDb StatefulSets) instead of treating a successful write as
+// done.
+package statuscheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	deploymentutil "k8s.io/kubectl/pkg/util/deployment"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/splunk/splunk-operator/controllers/ray/v1"
+)
+
+// ReadyChecker determines readiness of a child resource by kind, following the
+// same per-kind rules Helm 3 uses to decide whether `helm upgrade --wait` can
+// return.
+type ReadyChecker struct {
+	client.Client
+}
+
+// NewReadyChecker returns a ReadyChecker that reads resource status through c.
+func NewReadyChecker(c client.Client) *ReadyChecker {
+	return &ReadyChecker{Client: c}
+}
+
+// IsReady reports whether obj has reached a ready state, dispatching on its
+// concrete type. Kinds this checker does not recognize are treated as ready,
+// since the caller is only ever asked to wait on the concrete types it creates.
+func (rc *ReadyChecker) IsReady(ctx context.Context, obj client.Object) (bool, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return rc.deploymentReady(ctx, o)
+	case *appsv1.StatefulSet:
+		return rc.statefulSetReady(ctx, o)
+	case *corev1.Pod:
+		return rc.podReady(o), nil
+	case *corev1.PersistentVolumeClaim:
+		return rc.pvcReady(o), nil
+	case *corev1.Service:
+		return rc.serviceReady(ctx, o)
+	case *batchv1.Job:
+		return rc.jobReady(o), nil
+	case *rayv1.RayService:
+		return rc.rayServiceReady(ctx, o)
+	default:
+		return true, nil
+	}
+}
+
+// deploymentReady waits for the rollout to report Progressing/NewReplicaSetAvailable,
+// the same signal `kubectl rollout status` and Helm 3 use for Deployments.
+func (rc *ReadyChecker) deploymentReady(ctx context.Context, dep *appsv1.Deployment) (bool, error) {
+	current := &appsv1.Deployment{}
+	if err := rc.Get(ctx, client.ObjectKeyFromObject(dep), current); err != nil {
+		return false, err
+	}
+
+	cond := deploymentutil.GetDeploymentCondition(current.Status, appsv1.DeploymentProgressing)
+	if cond == nil || cond.Reason != deploymentutil.NewRSAvailableReason {
+		return false, nil
+	}
+	return current.Status.UpdatedReplicas == *current.Spec.Replicas &&
+		current.Status.AvailableReplicas == *current.Spec.Replicas, nil
+}
+
+// statefulSetReady compares updatedReplicas against the spec and requires the
+// update and current revisions to have converged, matching Helm 3's StatefulSet check.
+func (rc *ReadyChecker) statefulSetReady(ctx context.Context, sts *appsv1.StatefulSet) (bool, error) {
+	current := &appsv1.StatefulSet{}
+	if err := rc.Get(ctx, client.ObjectKeyFromObject(sts), current); err != nil {
+		return false, err
+	}
+
+	if current.Spec.Replicas != nil && current.Status.UpdatedReplicas < *current.Spec.Replicas {
+		return false, nil
+	}
+	if current.Status.UpdateRevision != "" && current.Status.CurrentRevision != current.Status.UpdateRevision {
+		return false, nil
+	}
+	return true, nil
+}
+
+// podReady requires the PodReady condition to be true.
+func (rc *ReadyChecker) podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// pvcReady requires the claim to have bound.
+func (rc *ReadyChecker) pvcReady(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Status.Phase == corev1.ClaimBound
+}
+
+// serviceReady requires the Service to have at least one ready endpoint, unless it
+// is ExternalName, which has no endpoints to wait on. Headless Services are
+// checked the same way as any other.
+func (rc *ReadyChecker) serviceReady(ctx context.Context, svc *corev1.Service) (bool, error) {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return true, nil
+	}
+
+	endpoints := &corev1.Endpoints{}
+	if err := rc.Get(ctx, client.ObjectKeyFromObject(svc), endpoints); err != nil {
+		return false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rayServiceReady reports whether the RayService's active cluster has settled,
+// which is as close as the Status subresource gets to "Serve app is live".
+func (rc *ReadyChecker) rayServiceReady(ctx context.Context, rs *rayv1.RayService) (bool, error) {
+	current := &rayv1.RayService{}
+	if err := rc.Get(ctx, client.ObjectKeyFromObject(rs), current); err != nil {
+		return false, err
+	}
+	return current.Status.ActiveServiceStatus.RayClusterName != "" &&
+		string(current.Status.ActiveServiceStatus.RayClusterStatus.State) == "ready", nil
+}
+
+// jobReady requires the Job to report a Complete condition.
+func (rc *ReadyChecker) jobReady(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForResources polls every object in objs until IsReady is true for all of
+// them or timeout elapses, whichever comes first. It returns nil only once
+// everything is ready; callers that hit the deadline should requeue rather than
+// treat the rollout as failed, since a slow-but-healthy rollout looks identical
+// to a stuck one from here.
+func (rc *ReadyChecker) WaitForResources(ctx context.Context, timeout time.Duration, objs []client.Object) error {
+	deadline := time.Now().Add(timeout)
+	const pollInterval = 2 * time.Second
+
+	for {
+		allReady := true
+		for _, obj := range objs {
+			ready, err := rc.IsReady(ctx, obj)
+			if err != nil {
+				return fmt.Errorf("failed to check readiness of %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+			}
+			if !ready {
+				allReady = false
+				break
+			}
+		}
+		if allReady {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s waiting for %d resource(s) to become ready", timeout, len(objs))
+		}
+		sleep := pollInterval
+		if remaining < sleep {
+			sleep = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleep):
+		}
+	}
+}
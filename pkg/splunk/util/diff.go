@@ -0,0 +1,130 @@
+// Copyright (c) 2018-2021 Splunk Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LastAppliedConfigAnnotation stores the previously applied object state,
+// mirroring `kubectl apply`'s own bookkeeping. It lets ApplyWithMerge tell a
+// field the spec stopped setting apart from a field some other actor set
+// out-of-band, which a naive desired-vs-current diff can't do.
+const LastAppliedConfigAnnotation = "splunk.com/last-applied-configuration"
+
+// ApplyWithMerge reconciles existing toward desired using a three-way
+// strategic merge patch -- original is the object's last-applied-configuration
+// annotation, modified is desired, current is existing -- instead of comparing
+// a hand-picked handful of fields. This catches changes to resources, volumes,
+// tolerations, affinity, scheduler name, and topology spread constraints that a
+// replicas/image-only comparison silently drops.
+//
+// existing must be an addressable zero value of the same concrete type as
+// desired (e.g. &appsv1.Deployment{}); ApplyWithMerge populates it via Get when
+// the object already exists. When the object is created instead, existing is
+// left as it was passed in. recorder may be nil, in which case no Event is emitted. It returns whether
+// the call created or changed the object.
+func ApplyWithMerge(ctx context.Context, c client.Client, recorder record.EventRecorder, owner, desired, existing client.Object) (bool, error) {
+	key := client.ObjectKeyFromObject(desired)
+	kind := fmt.Sprintf("%T", desired)
+
+	err := c.Get(ctx, key, existing)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+
+		if err := setLastAppliedConfig(desired); err != nil {
+			return false, err
+		}
+		if err := c.Create(ctx, desired); err != nil {
+			return false, fmt.Errorf("failed to create %s %s: %w", kind, key, err)
+		}
+		recordEvent(recorder, owner, "Created", "Created %s %s", kind, key)
+		return true, nil
+	}
+
+	original := []byte(existing.GetAnnotations()[LastAppliedConfigAnnotation])
+
+	current, err := json.Marshal(existing)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal current %s %s: %w", kind, key, err)
+	}
+	if err := setLastAppliedConfig(desired); err != nil {
+		return false, err
+	}
+	modified, err := json.Marshal(desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal desired %s %s: %w", kind, key, err)
+	}
+
+	patchMeta, err := strategicpatch.NewPatchMetaFromStruct(desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to build patch metadata for %s %s: %w", kind, key, err)
+	}
+	patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, patchMeta, true)
+	if err != nil {
+		return false, fmt.Errorf("failed to compute three-way merge patch for %s %s: %w", kind, key, err)
+	}
+	if string(patch) == "{}" {
+		recordEvent(recorder, owner, "NoChange", "%s %s already matches desired state", kind, key)
+		return false, nil
+	}
+
+	if err := c.Patch(ctx, existing, client.RawPatch(types.StrategicMergePatchType, patch)); err != nil {
+		return false, fmt.Errorf("failed to patch %s %s: %w", kind, key, err)
+	}
+	recordEvent(recorder, owner, "Updated", "Updated %s %s", kind, key)
+	return true, nil
+}
+
+// setLastAppliedConfig stamps obj's own serialized state onto itself as the
+// last-applied-configuration annotation, the same two-pass trick `kubectl
+// apply` uses so the annotation's own presence doesn't perturb the diff it's
+// used to compute on the next reconcile.
+func setLastAppliedConfig(obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, LastAppliedConfigAnnotation)
+	obj.SetAnnotations(annotations)
+
+	config, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal last-applied-configuration: %w", err)
+	}
+
+	annotations[LastAppliedConfigAnnotation] = string(config)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// recordEvent emits an Event through recorder if one was supplied; callers
+// that don't have an EventRecorder wired up yet (e.g. in unit tests) pass nil.
+func recordEvent(recorder record.EventRecorder, owner client.Object, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(owner, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018-2021 Splunk Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func baseDeployment() *appsv1.Deployment {
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sais-service",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sais-service"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "sais-service"}},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"disktype": "ssd"},
+					Containers: []corev1.Container{
+						{
+							Name:  "sais-service-container",
+							Image: "sais-service:1.0",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							},
+							Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "cache", MountPath: "/cache"},
+							},
+						},
+					},
+					Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+					Volumes: []corev1.Volume{
+						{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					},
+					SchedulerName: "default-scheduler",
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1a"}},
+										},
+									},
+								},
+							},
+						},
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: corev1.DoNotSchedule,
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sais-service"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyTwice runs ApplyWithMerge once to create the object and a second time with
+// mutate applied to the desired state, returning whether the second call reported
+// a change.
+func applyTwice(t *testing.T, mutate func(*appsv1.Deployment)) bool {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to register appsv1 scheme: %v", err)
+	}
+	var c client.Client = fake.NewClientBuilder().WithScheme(scheme).Build()
+	owner := baseDeployment()
+
+	desired := baseDeployment()
+	existing := &appsv1.Deployment{}
+	if _, err := ApplyWithMerge(context.Background(), c, nil, owner, desired, existing); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	desired = baseDeployment()
+	mutate(desired)
+	existing = &appsv1.Deployment{}
+	changed, err := ApplyWithMerge(context.Background(), c, nil, owner, desired, existing)
+	if err != nil {
+		t.Fatalf("failed to reconcile mutated deployment: %v", err)
+	}
+	return changed
+}
+
+func TestApplyWithMergeDetectsFieldChanges(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*appsv1.Deployment)
+	}{
+		{"replicas", func(d *appsv1.Deployment) { replicas := int32(5); d.Spec.Replicas = &replicas }},
+		{"image", func(d *appsv1.Deployment) { d.Spec.Template.Spec.Containers[0].Image = "sais-service:2.0" }},
+		{"resources", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+		}},
+		{"env", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Containers[0].Env = append(d.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "NEW_VAR", Value: "x"})
+		}},
+		{"nodeSelector", func(d *appsv1.Deployment) { d.Spec.Template.Spec.NodeSelector = map[string]string{"disktype": "nvme"} }},
+		{"tolerations", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Tolerations = append(d.Spec.Template.Spec.Tolerations, corev1.Toleration{Key: "gpu", Operator: corev1.TolerationOpExists})
+		}},
+		{"volumes", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name:         "scratch",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			})
+		}},
+		{"affinity", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values = []string{"us-west-2a"}
+		}},
+		{"schedulerName", func(d *appsv1.Deployment) { d.Spec.Template.Spec.SchedulerName = "gpu-scheduler" }},
+		{"topologySpreadConstraints", func(d *appsv1.Deployment) {
+			d.Spec.Template.Spec.TopologySpreadConstraints = append(d.Spec.Template.Spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+				MaxSkew:           2,
+				TopologyKey:       "kubernetes.io/hostname",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			})
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if changed := applyTwice(t, tc.mutate); !changed {
+				t.Errorf("expected ApplyWithMerge to report a change after mutating %s", tc.name)
+			}
+		})
+	}
+}
+
+func TestApplyWithMergeNoChange(t *testing.T) {
+	if changed := applyTwice(t, func(*appsv1.Deployment) {}); changed {
+		t.Error("expected ApplyWithMerge to report no change when desired state is unchanged")
+	}
+}